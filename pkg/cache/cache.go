@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// schemaVersion is bumped whenever the shape of a cached record changes in a
+// way that would make previously-persisted entries unsafe to decode. It is
+// folded into every cache key so that a backend shared across a rolling
+// deploy (e.g. Redis) never hands an old process a record it can't parse, or
+// a new process a stale record it would misinterpret.
+const schemaVersion = 1
+
+// KeyPrefix is prepended to every key a Cache writes. Backends that share
+// storage with other tools (e.g. a Redis database) can use it to scope a
+// flush to only the keys this cache owns.
+var KeyPrefix = fmt.Sprintf("dss:v%d:", schemaVersion)
+
+// Backend is the storage engine behind a Cache. Implementations range from a
+// process-local map to Redis or an on-disk store, so that long-running or
+// multi-node deployments can share scan results across restarts instead of
+// re-issuing every BIMI/DKIM/DMARC/SPF/MX/MTA-STS lookup on every startup.
+type Backend interface {
+	// Get returns the raw value stored for key, and false if it is absent or
+	// expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value for key, to be evicted after ttl elapses. A zero ttl
+	// means the entry never expires.
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Flush removes every entry from the backend.
+	Flush() error
+
+	// TTL returns the default time-to-live the backend was configured with.
+	TTL() time.Duration
+}
+
+// Cache is a typed, schema-versioned wrapper around a Backend. It handles key
+// namespacing and JSON (de)serialization so that backends only have to deal
+// in bytes.
+type Cache[T any] struct {
+	backend Backend
+}
+
+// New creates a Cache backed by backend. If backend is nil, an in-memory
+// backend with the given ttl is used, preserving the previous default
+// behavior of the scanner.
+func New[T any](ttl time.Duration, backend Backend) *Cache[T] {
+	if backend == nil {
+		backend = NewMemoryBackend(ttl)
+	}
+
+	return &Cache[T]{backend: backend}
+}
+
+// key namespaces a lookup key with the cache's schema version so record
+// shapes can evolve without colliding with entries written by an older
+// version of the scanner.
+func key(k string) string {
+	return fmt.Sprintf("%s%s", KeyPrefix, k)
+}
+
+// Get returns the cached value for k, or nil if it is missing, expired, or
+// fails to decode.
+func (c *Cache[T]) Get(k string) *T {
+	raw, ok := c.backend.Get(key(k))
+	if !ok {
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+
+	return &value
+}
+
+// Set stores value for k using the backend's configured TTL.
+func (c *Cache[T]) Set(k string, value *T) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "marshal cache entry")
+	}
+
+	if err := c.backend.Set(key(k), raw, c.backend.TTL()); err != nil {
+		return errors.Wrap(err, "write cache entry")
+	}
+
+	return nil
+}
+
+// Flush removes every entry from the underlying backend.
+func (c *Cache[T]) Flush() {
+	_ = c.backend.Flush()
+}
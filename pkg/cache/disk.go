@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+var diskBucket = []byte("cache")
+
+// DiskBackend is a Backend that persists entries to a BoltDB file on disk,
+// so that a scanner process picks up where a previous run left off across
+// restarts.
+type DiskBackend struct {
+	db  *bolt.DB
+	ttl time.Duration
+}
+
+// NewDiskBackend opens (creating if necessary) a BoltDB database at path.
+// Entries written without an explicit ttl fall back to ttl.
+func NewDiskBackend(path string, ttl time.Duration) (*DiskBackend, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "open disk cache")
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(diskBucket)
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "create cache bucket")
+	}
+
+	return &DiskBackend{db: db, ttl: ttl}, nil
+}
+
+// encodeDiskEntry prefixes the stored value with its expiry, encoded as a Unix
+// nanosecond timestamp (0 meaning "never expires").
+func encodeDiskEntry(value []byte, expiresAt time.Time) []byte {
+	var nanos uint64
+	if !expiresAt.IsZero() {
+		nanos = uint64(expiresAt.UnixNano())
+	}
+
+	buf := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(buf[:8], nanos)
+	copy(buf[8:], value)
+
+	return buf
+}
+
+func decodeDiskEntry(raw []byte) ([]byte, time.Time) {
+	if len(raw) < 8 {
+		return nil, time.Time{}
+	}
+
+	nanos := int64(binary.BigEndian.Uint64(raw[:8]))
+
+	var expiresAt time.Time
+	if nanos != 0 {
+		expiresAt = time.Unix(0, nanos)
+	}
+
+	return raw[8:], expiresAt
+}
+
+func (d *DiskBackend) Get(key string) ([]byte, bool) {
+	var value []byte
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(diskBucket).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+
+		decoded, expiresAt := decodeDiskEntry(raw)
+		if !expiresAt.IsZero() && time.Now().After(expiresAt) {
+			return nil
+		}
+
+		value = decoded
+
+		return nil
+	})
+
+	return value, err == nil && value != nil
+}
+
+func (d *DiskBackend) Set(key string, value []byte, ttl time.Duration) error {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(diskBucket).Put([]byte(key), encodeDiskEntry(value, expiresAt))
+	})
+}
+
+func (d *DiskBackend) Flush() error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(diskBucket); err != nil {
+			return err
+		}
+
+		_, err := tx.CreateBucket(diskBucket)
+
+		return err
+	})
+}
+
+func (d *DiskBackend) TTL() time.Duration {
+	return d.ttl
+}
+
+// Close releases the underlying BoltDB file handle.
+func (d *DiskBackend) Close() error {
+	return d.db.Close()
+}
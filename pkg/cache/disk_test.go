@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDiskEntryRoundTrip(t *testing.T) {
+	expiresAt := time.Unix(0, time.Now().UnixNano())
+	value := []byte("cached value")
+
+	encoded := encodeDiskEntry(value, expiresAt)
+
+	decodedValue, decodedExpiresAt := decodeDiskEntry(encoded)
+	if !bytes.Equal(decodedValue, value) {
+		t.Errorf("decodeDiskEntry value = %q, want %q", decodedValue, value)
+	}
+
+	if !decodedExpiresAt.Equal(expiresAt) {
+		t.Errorf("decodeDiskEntry expiresAt = %v, want %v", decodedExpiresAt, expiresAt)
+	}
+}
+
+func TestDiskEntryNeverExpires(t *testing.T) {
+	encoded := encodeDiskEntry([]byte("value"), time.Time{})
+
+	_, expiresAt := decodeDiskEntry(encoded)
+	if !expiresAt.IsZero() {
+		t.Errorf("decodeDiskEntry expiresAt = %v, want zero", expiresAt)
+	}
+}
+
+func TestDecodeDiskEntryTooShort(t *testing.T) {
+	value, expiresAt := decodeDiskEntry([]byte("abc"))
+	if value != nil || !expiresAt.IsZero() {
+		t.Errorf("decodeDiskEntry(short) = %v, %v, want nil, zero", value, expiresAt)
+	}
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryBackend is an in-memory Backend. It is the default used when no
+// other backend is configured, and is only ever visible to the process that
+// created it.
+type MemoryBackend struct {
+	mutex   sync.RWMutex
+	entries map[string]memoryEntry
+	ttl     time.Duration
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryBackend creates a MemoryBackend whose entries expire after ttl. A
+// zero ttl means entries never expire.
+func NewMemoryBackend(ttl time.Duration) *MemoryBackend {
+	return &MemoryBackend{
+		entries: make(map[string]memoryEntry),
+		ttl:     ttl,
+	}
+}
+
+func (m *MemoryBackend) Get(key string) ([]byte, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (m *MemoryBackend) Set(key string, value []byte, ttl time.Duration) error {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	m.mutex.Lock()
+	m.entries[key] = memoryEntry{value: value, expiresAt: expiresAt}
+	m.mutex.Unlock()
+
+	return nil
+}
+
+func (m *MemoryBackend) Flush() error {
+	m.mutex.Lock()
+	m.entries = make(map[string]memoryEntry)
+	m.mutex.Unlock()
+
+	return nil
+}
+
+func (m *MemoryBackend) TTL() time.Duration {
+	return m.ttl
+}
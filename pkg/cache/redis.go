@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBackend is a Backend that persists entries in Redis, letting multiple
+// scanner instances (or multiple runs of the same process) share results
+// instead of re-issuing lookups against every domain in a large portfolio.
+type RedisBackend struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisBackend creates a RedisBackend against addr (host:port), using db
+// and an optional password. Entries written without an explicit ttl fall
+// back to ttl.
+func NewRedisBackend(addr, password string, db int, ttl time.Duration) (*RedisBackend, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, errors.Wrap(err, "connect to redis")
+	}
+
+	return &RedisBackend{client: client, ttl: ttl}, nil
+}
+
+func (r *RedisBackend) Get(key string) ([]byte, bool) {
+	value, err := r.client.Get(context.Background(), key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (r *RedisBackend) Set(key string, value []byte, ttl time.Duration) error {
+	if err := r.client.Set(context.Background(), key, value, ttl).Err(); err != nil {
+		return errors.Wrap(err, "set redis key")
+	}
+
+	return nil
+}
+
+// Flush deletes every key under KeyPrefix, rather than the whole selected
+// Redis database, so a DB shared with other tools or applications is left
+// untouched.
+func (r *RedisBackend) Flush() error {
+	ctx := context.Background()
+
+	var cursor uint64
+
+	for {
+		keys, nextCursor, err := r.client.Scan(ctx, cursor, KeyPrefix+"*", 100).Result()
+		if err != nil {
+			return errors.Wrap(err, "scan redis keys")
+		}
+
+		if len(keys) > 0 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return errors.Wrap(err, "delete redis keys")
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *RedisBackend) TTL() time.Duration {
+	return r.ttl
+}
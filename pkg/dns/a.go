@@ -0,0 +1,25 @@
+package dns
+
+import (
+	"net"
+
+	"github.com/miekg/dns"
+)
+
+// GetTypeA returns the IPv4 addresses for domain.
+func (c *Client) GetTypeA(domain string) ([]net.IP, error) {
+	answers, err := c.exchange(domain, dns.TypeA)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []net.IP
+
+	for _, answer := range answers {
+		if a, ok := answer.(*dns.A); ok {
+			addresses = append(addresses, a.A)
+		}
+	}
+
+	return addresses, nil
+}
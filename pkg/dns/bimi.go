@@ -0,0 +1,20 @@
+package dns
+
+import "strings"
+
+// GetTypeBIMI returns the BIMI record published at default._bimi.<domain>, if
+// any.
+func (c *Client) GetTypeBIMI(domain string) (string, error) {
+	records, err := c.lookupTXT("default._bimi." + domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if strings.HasPrefix(record, "v=BIMI1") {
+			return record, nil
+		}
+	}
+
+	return "", nil
+}
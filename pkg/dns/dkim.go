@@ -0,0 +1,23 @@
+package dns
+
+import "strings"
+
+// defaultDKIMSelector is used when the caller doesn't know which selector a
+// domain publishes its DKIM key under.
+const defaultDKIMSelector = "default"
+
+// GetTypeDKIM returns the DKIM record for domain under the default selector.
+func (c *Client) GetTypeDKIM(domain string) (string, error) {
+	records, err := c.lookupTXT(defaultDKIMSelector + "._domainkey." + domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if strings.HasPrefix(record, "v=DKIM1") {
+			return record, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,19 @@
+package dns
+
+import "strings"
+
+// GetTypeDMARC returns the DMARC record published at _dmarc.<domain>, if any.
+func (c *Client) GetTypeDMARC(domain string) (string, error) {
+	records, err := c.lookupTXT("_dmarc." + domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if strings.HasPrefix(record, "v=DMARC1") {
+			return record, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,62 @@
+// Package dns wraps github.com/miekg/dns with the handful of lookups the
+// scanner needs (BIMI, DKIM, DMARC, MX, NS, SPF, MTA-STS, DNSSEC, ...),
+// sharing a single resolver and message buffer across all of them.
+package dns
+
+import (
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// Re-exported so callers don't need a direct miekg/dns import alongside this
+// package.
+const (
+	TypeA    = dns.TypeA
+	TypeAAAA = dns.TypeAAAA
+	TypeMX   = dns.TypeMX
+	TypeNS   = dns.TypeNS
+	TypePTR  = dns.TypePTR
+	TypeTLSA = dns.TypeTLSA
+	TypeTXT  = dns.TypeTXT
+)
+
+// NewZoneParser re-exports dns.NewZoneParser so callers parsing a zone file
+// don't need to import miekg/dns directly.
+var NewZoneParser = dns.NewZoneParser
+
+// Client is a shared DNS resolver used by every lookup the scanner performs.
+type Client struct {
+	client     *dns.Client
+	buffer     uint16
+	nameserver string
+	port       int
+}
+
+// New creates a Client that queries nameserver (or the system resolver, if
+// empty) on port, using buffer as the EDNS0 UDP payload size, timing each
+// query out after timeout.
+func New(timeout time.Duration, buffer uint16, port int, nameserver string) (*Client, error) {
+	if port == 0 {
+		port = 53
+	}
+
+	if nameserver == "" {
+		config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err == nil && len(config.Servers) > 0 {
+			nameserver = config.Servers[0]
+		} else {
+			nameserver = "8.8.8.8"
+		}
+	}
+
+	return &Client{
+		client: &dns.Client{
+			Net:     "udp",
+			Timeout: timeout,
+		},
+		buffer:     buffer,
+		nameserver: nameserver,
+		port:       port,
+	}, nil
+}
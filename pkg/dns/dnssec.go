@@ -0,0 +1,34 @@
+package dns
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// GetTypeDNSSEC returns a summary of the DNSKEY record(s) published for
+// domain, but only if the resolver reports the answer as authenticated (the
+// response's AD flag, obtained by querying with the EDNS0 DO bit set). This
+// means a non-empty result reflects a validated DNSSEC chain, not just the
+// presence of a DNSKEY record. DANE/TLSA trust is not derived from this
+// result; GetTypeTLSA reports authentication per MX host instead.
+func (c *Client) GetTypeDNSSEC(domain string) (string, error) {
+	answers, authenticated, err := c.exchangeSecure(domain, dns.TypeDNSKEY)
+	if err != nil {
+		return "", err
+	}
+
+	if !authenticated {
+		return "", nil
+	}
+
+	var records []string
+
+	for _, answer := range answers {
+		if key, ok := answer.(*dns.DNSKEY); ok {
+			records = append(records, key.String())
+		}
+	}
+
+	return strings.Join(records, "\n"), nil
+}
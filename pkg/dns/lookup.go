@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+// exchange sends a single question of the given rrType for name and returns
+// the answer section.
+func (c *Client) exchange(name string, rrType uint16) ([]dns.RR, error) {
+	msg := new(dns.Msg)
+	msg.SetEdns0(c.buffer, false)
+	msg.SetQuestion(dns.Fqdn(name), rrType)
+	msg.RecursionDesired = true
+
+	response, _, err := c.client.Exchange(msg, net.JoinHostPort(c.nameserver, fmt.Sprintf("%d", c.port)))
+	if err != nil {
+		return nil, errors.Wrap(err, "exchange dns query")
+	}
+
+	if response.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("dns query failed with rcode %s", dns.RcodeToString[response.Rcode])
+	}
+
+	return response.Answer, nil
+}
+
+// exchangeSecure is exchange, but with the EDNS0 DO (DNSSEC OK) bit set so a
+// validating resolver returns RRSIGs and reports whether it authenticated
+// the answer via the response's AD flag.
+func (c *Client) exchangeSecure(name string, rrType uint16) (answers []dns.RR, authenticated bool, err error) {
+	msg := new(dns.Msg)
+	msg.SetEdns0(c.buffer, true)
+	msg.SetQuestion(dns.Fqdn(name), rrType)
+	msg.RecursionDesired = true
+
+	response, _, err := c.client.Exchange(msg, net.JoinHostPort(c.nameserver, fmt.Sprintf("%d", c.port)))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "exchange dns query")
+	}
+
+	if response.Rcode != dns.RcodeSuccess {
+		return nil, false, fmt.Errorf("dns query failed with rcode %s", dns.RcodeToString[response.Rcode])
+	}
+
+	return response.Answer, response.AuthenticatedData, nil
+}
+
+// GetDNSAnswers returns the raw answer section for name and rrType, letting
+// callers that need more than a single TXT/MX/etc. value inspect the records
+// directly.
+func (c *Client) GetDNSAnswers(name string, rrType uint16) ([]dns.RR, error) {
+	return c.exchange(name, rrType)
+}
+
+// lookupTXT returns every TXT record for name, with surrounding quotes
+// stripped and multi-string records joined.
+func (c *Client) lookupTXT(name string) ([]string, error) {
+	answers, err := c.exchange(name, dns.TypeTXT)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+
+	for _, answer := range answers {
+		if txt, ok := answer.(*dns.TXT); ok {
+			records = append(records, strings.Join(txt.Txt, ""))
+		}
+	}
+
+	return records, nil
+}
+
+// GetTypeNS returns the NS records for domain.
+func (c *Client) GetTypeNS(domain string) ([]string, error) {
+	answers, err := c.exchange(domain, dns.TypeNS)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+
+	for _, answer := range answers {
+		if ns, ok := answer.(*dns.NS); ok {
+			records = append(records, strings.TrimSuffix(ns.Ns, "."))
+		}
+	}
+
+	return records, nil
+}
+
+// GetTypeMX returns the MX hostnames for domain, ordered by preference.
+func (c *Client) GetTypeMX(domain string) ([]string, error) {
+	answers, err := c.exchange(domain, dns.TypeMX)
+	if err != nil {
+		return nil, err
+	}
+
+	var mxs []*dns.MX
+
+	for _, answer := range answers {
+		if mx, ok := answer.(*dns.MX); ok {
+			mxs = append(mxs, mx)
+		}
+	}
+
+	sort.SliceStable(mxs, func(i, j int) bool { return mxs[i].Preference < mxs[j].Preference })
+
+	var records []string
+
+	for _, mx := range mxs {
+		records = append(records, strings.TrimSuffix(mx.Mx, "."))
+	}
+
+	return records, nil
+}
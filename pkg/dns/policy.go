@@ -0,0 +1,191 @@
+package dns
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxPolicySize is the RFC 8461 section 3.2 recommended cap on how large
+	// a policy document is allowed to be.
+	maxPolicySize = 64 * 1024
+
+	// RecommendedSTSMaxAge is the max_age RFC 8461 recommends a policy
+	// advertise, so caches don't have to re-check too often.
+	RecommendedSTSMaxAge = 604800
+)
+
+type (
+	// MTASTSPolicyMode is the `mode` directive of an MTA-STS policy.
+	MTASTSPolicyMode string
+
+	// MTASTSPolicy is a parsed, validated MTA-STS policy document (RFC 8461
+	// section 3.2), plus the bookkeeping needed to know when to refresh it.
+	MTASTSPolicy struct {
+		Version     string           `json:"version"`
+		Mode        MTASTSPolicyMode `json:"mode"`
+		MX          []string         `json:"mx"`
+		MaxAge      int              `json:"maxAge"`
+		ID          string           `json:"id"`
+		RetrievedAt time.Time        `json:"retrievedAt"`
+		ExpiresAt   time.Time        `json:"expiresAt"`
+	}
+)
+
+const (
+	MTASTSModeEnforce MTASTSPolicyMode = "enforce"
+	MTASTSModeTesting MTASTSPolicyMode = "testing"
+	MTASTSModeNone    MTASTSPolicyMode = "none"
+)
+
+// mxPatternRegexp matches either a plain hostname or a `*.` wildcard limited
+// to one additional label, per RFC 8461 section 4.1. The final label may
+// contain digits and interior hyphens so ACE-encoded (punycode) TLDs like
+// xn--p1ai validate correctly.
+var mxPatternRegexp = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)+[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]$`)
+
+// ExtractSTSID returns the `id=` tag of a raw MTA-STS TXT record, or "" if
+// the record doesn't carry one.
+func ExtractSTSID(record string) string {
+	for _, part := range strings.Split(record, ";") {
+		part = strings.TrimSpace(part)
+		if value, ok := strings.CutPrefix(part, "id="); ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// MatchesMX reports whether mxHost satisfies one of the policy's mx
+// patterns, per RFC 8461 section 4.1 (a `*.` wildcard only matches a single
+// additional label).
+func (p *MTASTSPolicy) MatchesMX(mxHost string) bool {
+	mxHost = strings.ToLower(strings.TrimSuffix(mxHost, "."))
+
+	for _, pattern := range p.MX {
+		pattern = strings.ToLower(pattern)
+
+		if !strings.HasPrefix(pattern, "*.") {
+			if pattern == mxHost {
+				return true
+			}
+
+			continue
+		}
+
+		suffix := pattern[1:] // ".example.com"
+		if !strings.HasSuffix(mxHost, suffix) {
+			continue
+		}
+
+		label := strings.TrimSuffix(mxHost, suffix)
+		if label != "" && !strings.Contains(label, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FetchMTASTSPolicy retrieves and validates the policy document published
+// over HTTPS at mta-sts.<domain>/.well-known/mta-sts.txt, tagging it with id
+// (the TXT record's `id=` value, used to detect changes between refreshes).
+func (c *Client) FetchMTASTSPolicy(domain, id string) (*MTASTSPolicy, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	response, err := client.Get("https://mta-sts." + domain + "/.well-known/mta-sts.txt")
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch mta-sts policy")
+	}
+	defer response.Body.Close()
+
+	if contentType := response.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		return nil, fmt.Errorf("unexpected content-type %q", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxPolicySize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "read mta-sts policy")
+	}
+
+	if len(body) > maxPolicySize {
+		return nil, fmt.Errorf("policy exceeds %d byte size cap", maxPolicySize)
+	}
+
+	return ParseMTASTSPolicy(id, string(body))
+}
+
+// ParseMTASTSPolicy parses a raw MTA-STS policy document per RFC 8461
+// section 3.2 and validates its structure.
+func ParseMTASTSPolicy(id, raw string) (*MTASTSPolicy, error) {
+	policy := &MTASTSPolicy{
+		ID:          id,
+		RetrievedAt: time.Now(),
+	}
+
+	var sawMaxAge bool
+
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed policy line: %q", line)
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		switch key {
+		case "version":
+			policy.Version = value
+		case "mode":
+			policy.Mode = MTASTSPolicyMode(value)
+		case "mx":
+			policy.MX = append(policy.MX, value)
+		case "max_age":
+			maxAge, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_age %q", value)
+			}
+
+			policy.MaxAge = maxAge
+			sawMaxAge = true
+		}
+	}
+
+	if !sawMaxAge {
+		return nil, errors.New("policy is missing the required max_age directive")
+	}
+
+	if policy.Version != "STSv1" {
+		return nil, fmt.Errorf("unsupported policy version %q", policy.Version)
+	}
+
+	switch policy.Mode {
+	case MTASTSModeEnforce, MTASTSModeTesting, MTASTSModeNone:
+	default:
+		return nil, fmt.Errorf("invalid policy mode %q", policy.Mode)
+	}
+
+	for _, pattern := range policy.MX {
+		if !mxPatternRegexp.MatchString(pattern) {
+			return nil, fmt.Errorf("invalid mx pattern %q", pattern)
+		}
+	}
+
+	policy.ExpiresAt = policy.RetrievedAt.Add(time.Duration(policy.MaxAge) * time.Second)
+
+	return policy, nil
+}
@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// minRefreshInterval bounds how often PeriodicallyRefresh re-checks a
+// domain it is already tracking, regardless of max_age, so a misconfigured
+// policy with a tiny max_age can't be used to hammer a domain's mta-sts
+// host.
+const minRefreshInterval = time.Minute
+
+// PolicyCache stores parsed MTA-STS policies on disk, keyed by domain, and
+// can periodically re-fetch them before they expire. It only re-downloads
+// the HTTPS policy document when the domain's TXT `id=` tag has changed
+// since the last check.
+type PolicyCache struct {
+	dir         string
+	fetchID     func(domain string) (string, error)
+	fetchPolicy func(domain, id string) (*MTASTSPolicy, error)
+
+	mutex    sync.Mutex
+	policies map[string]*MTASTSPolicy
+}
+
+// NewPolicyCache creates a PolicyCache that persists policy files under dir.
+// fetchID cheaply retrieves a domain's current TXT `id=` tag; fetchPolicy
+// downloads and parses the full policy document and is only called when
+// fetchID reports a change.
+func NewPolicyCache(dir string, fetchID func(domain string) (string, error), fetchPolicy func(domain, id string) (*MTASTSPolicy, error)) (*PolicyCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "create policy cache dir")
+	}
+
+	return &PolicyCache{
+		dir:         dir,
+		fetchID:     fetchID,
+		fetchPolicy: fetchPolicy,
+		policies:    make(map[string]*MTASTSPolicy),
+	}, nil
+}
+
+// path returns the on-disk location for domain's policy file. It's keyed by
+// a hash of the domain, rather than the domain itself, so that a hostile or
+// malformed domain (e.g. one containing "../", read from a zone file via
+// ScanZone) can't escape dir.
+func (c *PolicyCache) path(domain string) string {
+	sum := sha256.Sum256([]byte(domain))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns a copy of the cached policy for domain, loading it from disk
+// first if it isn't already in memory. The caller's copy is safe to read
+// without synchronization even while a refresh is in progress.
+func (c *PolicyCache) Get(domain string) *MTASTSPolicy {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if policy, ok := c.policies[domain]; ok {
+		copied := *policy
+		return &copied
+	}
+
+	data, err := os.ReadFile(c.path(domain))
+	if err != nil {
+		return nil
+	}
+
+	var policy MTASTSPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil
+	}
+
+	c.policies[domain] = &policy
+	copied := policy
+
+	return &copied
+}
+
+// Put stores policy as the current cached policy for domain, persisting it
+// to disk. It's used to seed the cache from a policy the caller already
+// fetched and parsed, e.g. during a regular domain scan, so
+// PeriodicallyRefresh has something to track.
+func (c *PolicyCache) Put(domain string, policy *MTASTSPolicy) error {
+	return c.persist(domain, policy)
+}
+
+// persist writes policy to disk and updates the in-memory copy.
+func (c *PolicyCache) persist(domain string, policy *MTASTSPolicy) error {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return errors.Wrap(err, "marshal policy")
+	}
+
+	if err := os.WriteFile(c.path(domain), data, 0o600); err != nil {
+		return errors.Wrap(err, "persist policy")
+	}
+
+	c.mutex.Lock()
+	c.policies[domain] = policy
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// Refresh checks domain's current TXT `id=` tag and, if it has changed
+// since the last refresh, downloads and parses the new policy document. It
+// returns the resulting policy and whether the id had changed.
+func (c *PolicyCache) Refresh(domain string) (policy *MTASTSPolicy, idChanged bool, err error) {
+	existing := c.Get(domain)
+
+	id, err := c.fetchID(domain)
+	if err != nil {
+		return existing, false, err
+	}
+
+	if existing != nil && existing.ID == id {
+		refreshed := *existing
+		refreshed.RetrievedAt = time.Now()
+		refreshed.ExpiresAt = refreshed.RetrievedAt.Add(time.Duration(refreshed.MaxAge) * time.Second)
+
+		if err := c.persist(domain, &refreshed); err != nil {
+			return existing, false, err
+		}
+
+		return &refreshed, false, nil
+	}
+
+	fresh, err := c.fetchPolicy(domain, id)
+	if err != nil {
+		return existing, false, err
+	}
+
+	if err := c.persist(domain, fresh); err != nil {
+		return fresh, existing != nil, err
+	}
+
+	return fresh, existing != nil, nil
+}
+
+// PeriodicallyRefresh re-fetches every tracked domain's policy shortly
+// before its max_age expires. It blocks until ctx is cancelled.
+func (c *PolicyCache) PeriodicallyRefresh(ctx context.Context) {
+	ticker := time.NewTicker(minRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.mutex.Lock()
+			due := make([]string, 0, len(c.policies))
+
+			for domain, policy := range c.policies {
+				if time.Now().After(policy.ExpiresAt.Add(-minRefreshInterval)) {
+					due = append(due, domain)
+				}
+			}
+			c.mutex.Unlock()
+
+			for _, domain := range due {
+				_, _, _ = c.Refresh(domain)
+			}
+		}
+	}
+}
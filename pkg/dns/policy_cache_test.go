@@ -0,0 +1,29 @@
+package dns
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPolicyCachePathStaysWithinDir(t *testing.T) {
+	cache := &PolicyCache{dir: "/tmp/policy-cache"}
+
+	for _, domain := range []string{
+		"example.com",
+		"../../etc/passwd",
+		"../../../evil",
+		"foo/bar",
+	} {
+		path := cache.path(domain)
+
+		rel, err := filepath.Rel(cache.dir, path)
+		if err != nil {
+			t.Fatalf("path(%q) = %q: %v", domain, path, err)
+		}
+
+		if rel == ".." || strings.HasPrefix(rel, "../") {
+			t.Fatalf("path(%q) = %q escapes %q", domain, path, cache.dir)
+		}
+	}
+}
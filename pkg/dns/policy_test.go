@@ -0,0 +1,64 @@
+package dns
+
+import "testing"
+
+func TestParseMTASTSPolicy(t *testing.T) {
+	valid := "version: STSv1\nmode: enforce\nmx: mail.example.com\nmax_age: 604800\n"
+
+	policy, err := ParseMTASTSPolicy("20160831085700Z", valid)
+	if err != nil {
+		t.Fatalf("ParseMTASTSPolicy(valid) returned error: %v", err)
+	}
+
+	if policy.Version != "STSv1" || policy.Mode != MTASTSModeEnforce || policy.MaxAge != 604800 {
+		t.Fatalf("ParseMTASTSPolicy(valid) = %+v, unexpected fields", policy)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"missing max_age", "version: STSv1\nmode: enforce\nmx: mail.example.com\n"},
+		{"bad mode", "version: STSv1\nmode: bogus\nmx: mail.example.com\nmax_age: 604800\n"},
+		{"bad version", "version: STSv2\nmode: enforce\nmx: mail.example.com\nmax_age: 604800\n"},
+		{"malformed line", "version STSv1\n"},
+		{"invalid mx pattern", "version: STSv1\nmode: enforce\nmx: *.*.example.com\nmax_age: 604800\n"},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseMTASTSPolicy("id", test.raw); err == nil {
+			t.Errorf("ParseMTASTSPolicy(%s) expected error, got nil", test.name)
+		}
+	}
+}
+
+func TestMTASTSPolicyMatchesMX(t *testing.T) {
+	policy := &MTASTSPolicy{MX: []string{"mail.example.com", "*.mx.example.com"}}
+
+	tests := []struct {
+		host  string
+		match bool
+	}{
+		{"mail.example.com", true},
+		{"mail.example.com.", true},
+		{"a.mx.example.com", true},
+		{"a.b.mx.example.com", false},
+		{"other.example.com", false},
+	}
+
+	for _, test := range tests {
+		if got := policy.MatchesMX(test.host); got != test.match {
+			t.Errorf("MatchesMX(%q) = %v, want %v", test.host, got, test.match)
+		}
+	}
+}
+
+func TestExtractSTSID(t *testing.T) {
+	if got := ExtractSTSID("v=STSv1; id=20160831085700Z"); got != "20160831085700Z" {
+		t.Errorf("ExtractSTSID = %q, want %q", got, "20160831085700Z")
+	}
+
+	if got := ExtractSTSID("v=STSv1"); got != "" {
+		t.Errorf("ExtractSTSID = %q, want empty", got)
+	}
+}
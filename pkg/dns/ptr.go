@@ -0,0 +1,31 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// GetTypePTR returns the PTR names published for ip (IPv4 or IPv6).
+func (c *Client) GetTypePTR(ip string) ([]string, error) {
+	reverse, err := dns.ReverseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("reverse address %s: %w", ip, err)
+	}
+
+	answers, err := c.exchange(strings.TrimSuffix(reverse, "."), dns.TypePTR)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, answer := range answers {
+		if ptr, ok := answer.(*dns.PTR); ok {
+			names = append(names, strings.TrimSuffix(ptr.Ptr, "."))
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,19 @@
+package dns
+
+import "strings"
+
+// GetTypeSPF returns the SPF record published directly on domain, if any.
+func (c *Client) GetTypeSPF(domain string) (string, error) {
+	records, err := c.lookupTXT(domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if strings.HasPrefix(record, "v=spf1") {
+			return record, nil
+		}
+	}
+
+	return "", nil
+}
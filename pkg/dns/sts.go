@@ -0,0 +1,67 @@
+package dns
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetTypeSTSRecord returns the MTA-STS TXT record published at
+// _mta-sts.<domain>, without fetching the (potentially expensive) HTTPS
+// policy document it points to.
+func (c *Client) GetTypeSTSRecord(domain string) (string, error) {
+	records, err := c.lookupTXT("_mta-sts." + domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range records {
+		if strings.HasPrefix(r, "v=STSv1") {
+			return r, nil
+		}
+	}
+
+	return "", nil
+}
+
+// GetTypeSTS returns the MTA-STS TXT record published at _mta-sts.<domain>,
+// along with the policy document retrieved over HTTPS from
+// mta-sts.<domain>/.well-known/mta-sts.txt, if the TXT record is present.
+func (c *Client) GetTypeSTS(domain string) (string, string, error) {
+	record, err := c.GetTypeSTSRecord(domain)
+	if err != nil || record == "" {
+		return "", "", err
+	}
+
+	policy, err := c.fetchSTSPolicy(domain)
+	if err != nil {
+		return record, "", err
+	}
+
+	return record, policy, nil
+}
+
+// fetchSTSPolicy retrieves the raw MTA-STS policy document for domain,
+// capped at maxPolicySize per RFC 8461 section 3.2.
+func (c *Client) fetchSTSPolicy(domain string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	response, err := client.Get("https://mta-sts." + domain + "/.well-known/mta-sts.txt")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxPolicySize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if len(body) > maxPolicySize {
+		return "", fmt.Errorf("policy exceeds %d byte size cap", maxPolicySize)
+	}
+
+	return string(body), nil
+}
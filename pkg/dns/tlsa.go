@@ -0,0 +1,29 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// GetTypeTLSA returns the TLSA (DANE) records published at
+// _25._tcp.<mxHost>, each formatted as "usage selector matching-type
+// certificate-association-data", along with whether the resolver
+// authenticated the DNSSEC chain for that lookup. DANE's security depends on
+// _25._tcp.<mxHost> itself being signed, which is a property of the MX
+// host's zone, not of whatever domain was originally scanned.
+func (c *Client) GetTypeTLSA(mxHost string) (records []string, authenticated bool, err error) {
+	answers, authenticated, err := c.exchangeSecure("_25._tcp."+mxHost, dns.TypeTLSA)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, answer := range answers {
+		if tlsa, ok := answer.(*dns.TLSA); ok {
+			records = append(records, fmt.Sprintf("%d %d %d %s", tlsa.Usage, tlsa.Selector, tlsa.MatchingType, strings.ToLower(tlsa.Certificate)))
+		}
+	}
+
+	return records, authenticated, nil
+}
@@ -0,0 +1,20 @@
+package dns
+
+import "strings"
+
+// GetTypeTLSRPT returns the TLS-RPT (RFC 8460) record published at
+// _smtp._tls.<domain>, if any.
+func (c *Client) GetTypeTLSRPT(domain string) (string, error) {
+	records, err := c.lookupTXT("_smtp._tls." + domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if strings.HasPrefix(record, "v=TLSRPTv1") {
+			return record, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,54 @@
+package scanner
+
+import (
+	"time"
+
+	"github.com/GlobalCyberAlliance/domain-security-scanner/v3/pkg/dns"
+)
+
+type (
+	// Finding is a single actionable observation the Advisor made about a
+	// Result, e.g. a missing or misconfigured record.
+	Finding struct {
+		Record   string `json:"record" yaml:"record" doc:"The record the finding concerns." example:"mta-sts"`
+		Severity string `json:"severity" yaml:"severity" doc:"How serious the finding is." example:"warning"`
+		Message  string `json:"message" yaml:"message" doc:"A human-readable description of the finding." example:"MTA-STS is in enforce mode but no TLS-RPT record was found."`
+	}
+
+	// Advisor inspects a Result and surfaces actionable findings about how a
+	// domain's mail security posture could be improved.
+	Advisor struct {
+		timeout       time.Duration
+		cacheDuration time.Duration
+
+		// policyCache, when set by the Scanner, lets checkSTSPolicy detect
+		// whether a domain's MTA-STS policy id changed since the last scan.
+		policyCache *dns.PolicyCache
+	}
+)
+
+const (
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// NewAdvisor creates an Advisor. timeout and cacheDuration are threaded
+// through to whatever checks need to perform their own lookups alongside the
+// scanner's.
+func NewAdvisor(timeout, cacheDuration time.Duration) *Advisor {
+	return &Advisor{
+		timeout:       timeout,
+		cacheDuration: cacheDuration,
+	}
+}
+
+// Advise runs every check against result and returns the findings raised.
+func (a *Advisor) Advise(result *Result) []Finding {
+	var findings []Finding
+
+	findings = append(findings, a.checkTLSRPT(result)...)
+	findings = append(findings, a.checkTLSA(result)...)
+	findings = append(findings, a.checkSTSPolicy(result)...)
+
+	return findings
+}
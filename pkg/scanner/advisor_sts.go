@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/GlobalCyberAlliance/domain-security-scanner/v3/pkg/dns"
+)
+
+// checkSTSPolicy validates the parsed MTA-STS policy against RFC 8461
+// recommendations and the domain's actual MX records, turning "we grabbed
+// the file" into an actionable compliance check.
+func (a *Advisor) checkSTSPolicy(result *Result) []Finding {
+	if result.STSPolicy == "" {
+		return nil
+	}
+
+	id := dns.ExtractSTSID(result.STS)
+
+	policy, err := dns.ParseMTASTSPolicy(id, result.STSPolicy)
+	if err != nil {
+		return []Finding{{
+			Record:   "mta-sts",
+			Severity: SeverityError,
+			Message:  "MTA-STS policy failed to parse: " + err.Error(),
+		}}
+	}
+
+	var findings []Finding
+
+	if policy.Mode == dns.MTASTSModeEnforce {
+		var matched bool
+
+		for _, mxHost := range result.MX {
+			if policy.MatchesMX(mxHost) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			findings = append(findings, Finding{
+				Record:   "mta-sts",
+				Severity: SeverityError,
+				Message:  "MTA-STS policy was fetched in enforce mode but none of the domain's MX records match its mx patterns.",
+			})
+		}
+	}
+
+	if policy.MaxAge < dns.RecommendedSTSMaxAge {
+		findings = append(findings, Finding{
+			Record:   "mta-sts",
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("MTA-STS policy max_age of %d is below the recommended %d seconds.", policy.MaxAge, dns.RecommendedSTSMaxAge),
+		})
+	}
+
+	if a.policyCache != nil {
+		if previous := a.policyCache.Get(result.Domain); previous != nil && previous.ID != policy.ID {
+			findings = append(findings, Finding{
+				Record:   "mta-sts",
+				Severity: SeverityWarning,
+				Message:  "MTA-STS policy id changed since the last scan; mx patterns may have been updated.",
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// recommendedTLSAUsages are the DANE usage values recommended for SMTP:
+// DANE-TA (2) and DANE-EE (3). Usages 0 (PKIX-TA) and 1 (PKIX-EE) still
+// require a publicly trusted CA chain, defeating much of the point of DANE.
+var recommendedTLSAUsages = map[string]bool{"2": true, "3": true}
+
+// checkTLSA warns about common DANE misconfigurations: records that can't be
+// trusted because the MX host's own zone isn't DNSSEC-signed (or the
+// configured resolver didn't authenticate it), unrecommended usage values,
+// weak matching types, and a live certificate that doesn't match what was
+// published.
+func (a *Advisor) checkTLSA(result *Result) []Finding {
+	var findings []Finding
+
+	for mxHost, records := range result.TLSA {
+		if len(records) == 0 {
+			continue
+		}
+
+		if !result.TLSADNSSEC[mxHost] {
+			findings = append(findings, Finding{
+				Record:   "tlsa",
+				Severity: SeverityWarning,
+				Message:  "TLSA records were found for " + mxHost + " but its DNSSEC chain wasn't authenticated by the configured resolver, so it can't be validated; treating DANE as insecure for this host.",
+			})
+
+			continue
+		}
+
+		for _, record := range records {
+			fields := strings.Fields(record)
+			if len(fields) != 4 {
+				findings = append(findings, Finding{
+					Record:   "tlsa",
+					Severity: SeverityError,
+					Message:  "TLSA record for " + mxHost + " is malformed: \"" + record + "\".",
+				})
+
+				continue
+			}
+
+			usage, selector, matchingType, certData := fields[0], fields[1], fields[2], fields[3]
+
+			if !recommendedTLSAUsages[usage] {
+				findings = append(findings, Finding{
+					Record:   "tlsa",
+					Severity: SeverityWarning,
+					Message:  "TLSA record for " + mxHost + " uses usage " + usage + "; usage 2 (DANE-TA) or 3 (DANE-EE) is recommended for SMTP.",
+				})
+			}
+
+			if matchingType != "1" {
+				findings = append(findings, Finding{
+					Record:   "tlsa",
+					Severity: SeverityWarning,
+					Message:  "TLSA record for " + mxHost + " uses matching type " + matchingType + "; SHA-256 (matching type 1) is recommended.",
+				})
+			}
+
+			if finding, ok := a.checkTLSACertificate(mxHost, selector, matchingType, certData); ok {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// checkTLSACertificate dials mxHost:25, negotiates STARTTLS, and compares the
+// certificate it presents against a published TLSA record. It fails open
+// (ok=false) whenever the host can't be reached within the advisor's
+// timeout, since unreachability is not itself a DANE misconfiguration.
+func (a *Advisor) checkTLSACertificate(mxHost, selector, matchingType, certData string) (Finding, bool) {
+	conn, err := net.DialTimeout("tcp", mxHost+":25", a.timeout)
+	if err != nil {
+		return Finding{}, false
+	}
+	defer conn.Close()
+
+	client, err := smtp.NewClient(conn, mxHost)
+	if err != nil {
+		return Finding{}, false
+	}
+	defer client.Close()
+
+	if err := client.StartTLS(&tls.Config{ServerName: mxHost}); err != nil {
+		return Finding{}, false
+	}
+
+	state, ok := client.TLSConnectionState()
+	if !ok || len(state.PeerCertificates) == 0 {
+		return Finding{}, false
+	}
+
+	cert := state.PeerCertificates[0]
+
+	actual := cert.Raw
+	if selector == "1" {
+		actual = cert.RawSubjectPublicKeyInfo
+	}
+
+	var digest string
+
+	switch matchingType {
+	case "0":
+		digest = fmt.Sprintf("%x", actual)
+	case "2":
+		sum := sha512.Sum512(actual)
+		digest = hex.EncodeToString(sum[:])
+	default:
+		sum := sha256.Sum256(actual)
+		digest = hex.EncodeToString(sum[:])
+	}
+
+	if !strings.EqualFold(digest, certData) {
+		return Finding{
+			Record:   "tlsa",
+			Severity: SeverityError,
+			Message:  "TLSA record for " + mxHost + " doesn't match the certificate currently presented over STARTTLS.",
+		}, true
+	}
+
+	return Finding{}, false
+}
@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckTLSAUnauthenticatedDNSSEC(t *testing.T) {
+	advisor := NewAdvisor(100*time.Millisecond, 0)
+
+	result := &Result{
+		TLSA: map[string][]string{
+			"mail.example.com": {"3 1 1 0123456789abcdef"},
+		},
+		TLSADNSSEC: map[string]bool{
+			"mail.example.com": false,
+		},
+	}
+
+	findings := advisor.checkTLSA(result)
+	if len(findings) != 1 {
+		t.Fatalf("checkTLSA() = %d findings, want 1; %+v", len(findings), findings)
+	}
+
+	if !strings.Contains(findings[0].Message, "DNSSEC chain wasn't authenticated") {
+		t.Errorf("finding message = %q, want it to mention unauthenticated DNSSEC", findings[0].Message)
+	}
+}
+
+func TestCheckTLSAMalformedRecord(t *testing.T) {
+	advisor := NewAdvisor(100*time.Millisecond, 0)
+
+	result := &Result{
+		TLSA: map[string][]string{
+			"mail.example.com": {"not-enough-fields"},
+		},
+		TLSADNSSEC: map[string]bool{
+			"mail.example.com": true,
+		},
+	}
+
+	findings := advisor.checkTLSA(result)
+	if len(findings) != 1 || findings[0].Severity != SeverityError {
+		t.Fatalf("checkTLSA() = %+v, want a single error finding", findings)
+	}
+}
+
+func TestCheckTLSAUsesPerHostAuthentication(t *testing.T) {
+	advisor := NewAdvisor(100*time.Millisecond, 0)
+
+	// Two MX hosts (loopback addresses, so checkTLSACertificate's dial fails
+	// fast instead of hitting real DNS/network), only one of which has an
+	// authenticated DNSSEC chain; each host's own status must be looked up
+	// independently rather than a single domain-wide flag deciding both.
+	const signedHost = "127.0.0.1"
+	const unsignedHost = "127.0.0.2"
+
+	result := &Result{
+		TLSA: map[string][]string{
+			signedHost:   {"2 1 1 0123456789abcdef"},
+			unsignedHost: {"2 1 1 0123456789abcdef"},
+		},
+		TLSADNSSEC: map[string]bool{
+			signedHost: true,
+			// unsignedHost intentionally absent (defaults to false).
+		},
+	}
+
+	findings := advisor.checkTLSA(result)
+
+	var sawUnsignedWarning bool
+
+	for _, finding := range findings {
+		if strings.Contains(finding.Message, unsignedHost) && strings.Contains(finding.Message, "DNSSEC chain wasn't authenticated") {
+			sawUnsignedWarning = true
+		}
+
+		if strings.Contains(finding.Message, signedHost) && strings.Contains(finding.Message, "DNSSEC chain wasn't authenticated") {
+			t.Errorf("%s incorrectly flagged as unauthenticated: %+v", signedHost, finding)
+		}
+	}
+
+	if !sawUnsignedWarning {
+		t.Errorf("expected an unauthenticated-DNSSEC warning for %s, got %+v", unsignedHost, findings)
+	}
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import "strings"
+
+// supportedTLSRPTSchemes lists the rua URI schemes mail receivers are
+// expected to support per RFC 8460 section 3.
+var supportedTLSRPTSchemes = []string{"mailto:", "https:"}
+
+// checkTLSRPT warns about common TLS-RPT misconfigurations: MTA-STS running
+// in enforce mode without a TLS-RPT record to report negotiation failures
+// against, rua URIs using schemes receivers won't understand, and records
+// that don't parse as TLS-RPT at all.
+func (a *Advisor) checkTLSRPT(result *Result) []Finding {
+	var findings []Finding
+
+	if result.TLSRPT == "" {
+		if strings.Contains(result.STSPolicy, "mode: enforce") {
+			findings = append(findings, Finding{
+				Record:   "tls-rpt",
+				Severity: SeverityWarning,
+				Message:  "MTA-STS is in enforce mode but no TLS-RPT record was found; TLS negotiation failures against this domain won't be reported.",
+			})
+		}
+
+		return findings
+	}
+
+	if !strings.HasPrefix(result.TLSRPT, "v=TLSRPTv1") {
+		findings = append(findings, Finding{
+			Record:   "tls-rpt",
+			Severity: SeverityError,
+			Message:  "TLS-RPT record is malformed; it must start with \"v=TLSRPTv1\".",
+		})
+
+		return findings
+	}
+
+	ruaIndex := strings.Index(result.TLSRPT, "rua=")
+	if ruaIndex == -1 {
+		findings = append(findings, Finding{
+			Record:   "tls-rpt",
+			Severity: SeverityError,
+			Message:  "TLS-RPT record is missing the required \"rua\" tag.",
+		})
+
+		return findings
+	}
+
+	rua := strings.TrimRight(result.TLSRPT[ruaIndex+len("rua="):], ";")
+
+	for _, uri := range strings.Split(rua, ",") {
+		uri = strings.TrimSpace(uri)
+
+		var supported bool
+
+		for _, scheme := range supportedTLSRPTSchemes {
+			if strings.HasPrefix(uri, scheme) {
+				supported = true
+				break
+			}
+		}
+
+		if !supported {
+			findings = append(findings, Finding{
+				Record:   "tls-rpt",
+				Severity: SeverityWarning,
+				Message:  "TLS-RPT rua URI \"" + uri + "\" uses an unsupported scheme; receivers typically only support mailto: and https:.",
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckTLSRPT(t *testing.T) {
+	advisor := NewAdvisor(100*time.Millisecond, 0)
+
+	tests := []struct {
+		name        string
+		result      *Result
+		wantCount   int
+		wantRecord  string
+		wantMessage string
+	}{
+		{
+			name:      "no tls-rpt, testing mode",
+			result:    &Result{STSPolicy: "version: STSv1\nmode: testing\nmax_age: 604800\n"},
+			wantCount: 0,
+		},
+		{
+			name:        "no tls-rpt, enforce mode",
+			result:      &Result{STSPolicy: "version: STSv1\nmode: enforce\nmax_age: 604800\n"},
+			wantCount:   1,
+			wantMessage: "no TLS-RPT record was found",
+		},
+		{
+			name:        "malformed record",
+			result:      &Result{TLSRPT: "v=TLSRPTv2; rua=mailto:reports@example.com"},
+			wantCount:   1,
+			wantMessage: "must start with \"v=TLSRPTv1\"",
+		},
+		{
+			name:        "missing rua",
+			result:      &Result{TLSRPT: "v=TLSRPTv1"},
+			wantCount:   1,
+			wantMessage: "missing the required \"rua\" tag",
+		},
+		{
+			name:      "supported rua schemes",
+			result:    &Result{TLSRPT: "v=TLSRPTv1; rua=mailto:reports@example.com,https://example.com/report"},
+			wantCount: 0,
+		},
+		{
+			name:        "unsupported rua scheme",
+			result:      &Result{TLSRPT: "v=TLSRPTv1; rua=ftp://example.com/report"},
+			wantCount:   1,
+			wantMessage: "unsupported scheme",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings := advisor.checkTLSRPT(test.result)
+			if len(findings) != test.wantCount {
+				t.Fatalf("checkTLSRPT() = %d findings, want %d; %+v", len(findings), test.wantCount, findings)
+			}
+
+			if test.wantMessage != "" && !strings.Contains(findings[0].Message, test.wantMessage) {
+				t.Errorf("finding message = %q, want it to contain %q", findings[0].Message, test.wantMessage)
+			}
+		})
+	}
+}
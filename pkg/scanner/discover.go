@@ -0,0 +1,241 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GlobalCyberAlliance/domain-security-scanner/v3/pkg/dns"
+	"github.com/pkg/errors"
+	boom "github.com/tylertreat/BoomFilters"
+)
+
+// subdomainAlterationTokens are prepended and appended during name
+// permutation, modelled on the common Amass-style alteration wordlists.
+var subdomainAlterationTokens = []string{"dev", "stage", "staging", "test", "qa", "uat", "vpn", "mail", "api", "internal", "corp"}
+
+// ctEntry is a single row of crt.sh's JSON output.
+type ctEntry struct {
+	NameValue string `json:"name_value"`
+}
+
+// ScanApex expands apex into candidate FQDNs via passive sources, wordlist
+// brute-forcing, and name permutation (see WithSubdomainDiscovery), then
+// scans every candidate that resolves. This lets an operator audit an
+// entire organization's mail posture starting from just its root domain.
+func (s *Scanner) ScanApex(ctx context.Context, apex string) ([]*Result, error) {
+	if apex == "" {
+		return nil, errors.New("empty apex domain")
+	}
+
+	candidates, err := s.discoverSubdomains(ctx, apex)
+	if err != nil {
+		return nil, errors.Wrap(err, "discover subdomains")
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	return s.Scan(candidates...)
+}
+
+// discoverSubdomains runs the passive, brute-force, and permutation stages
+// of subdomain discovery, resolves every candidate through the existing
+// ants.Pool, and deduplicates with a stable Bloom filter so memory stays
+// bounded on very large apexes.
+func (s *Scanner) discoverSubdomains(ctx context.Context, apex string) ([]string, error) {
+	seen := boom.NewDefaultStableBloomFilter(1000000, 0.01)
+
+	var mutex sync.Mutex
+	var resolved []string
+
+	add := func(candidate string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if seen.TestAndAdd([]byte(candidate)) {
+			return
+		}
+
+		resolved = append(resolved, candidate)
+	}
+
+	passive, err := s.passiveSubdomains(ctx, apex)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("apex", apex).Msg("passive subdomain discovery failed")
+	}
+
+	discovered := passive
+
+	if len(s.subdomainWordlist) > 0 {
+		discovered = append(discovered, s.bruteForceSubdomains(apex)...)
+	}
+
+	if s.subdomainPermute {
+		discovered = append(discovered, permuteSubdomains(discovered, apex)...)
+	}
+
+	var wg sync.WaitGroup
+
+	for _, candidate := range discovered {
+		candidate := candidate
+		wg.Add(1)
+
+		if err := s.pool.Submit(func() {
+			defer wg.Done()
+
+			if records, err := s.dnsClient.GetDNSAnswers(candidate, dns.TypeA); err == nil && len(records) > 0 {
+				add(candidate)
+			}
+		}); err != nil {
+			wg.Done()
+		}
+	}
+
+	wg.Wait()
+
+	return resolved, nil
+}
+
+// passiveSubdomains queries crt.sh's certificate transparency log search,
+// plus any additional JSON CT-style sources configured via
+// WithSubdomainDiscovery, for names under apex.
+func (s *Scanner) passiveSubdomains(ctx context.Context, apex string) ([]string, error) {
+	sources := append([]string{"https://crt.sh/?q=%25." + apex + "&output=json"}, s.subdomainSources...)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	var names []string
+
+	for _, source := range sources {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			continue
+		}
+
+		response, err := client.Do(request)
+		if err != nil {
+			continue
+		}
+
+		var entries []ctEntry
+
+		err = json.NewDecoder(response.Body).Decode(&entries)
+
+		response.Body.Close()
+
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			for _, name := range strings.Split(entry.NameValue, "\n") {
+				name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "*."))
+				if isSubdomainOf(name, apex) {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// isSubdomainOf reports whether name is apex itself or a subdomain of it,
+// anchored on the label boundary so that e.g. "evilexample.com" isn't
+// mistaken for a subdomain of apex "example.com".
+func isSubdomainOf(name, apex string) bool {
+	return name == apex || strings.HasSuffix(name, "."+apex)
+}
+
+// bruteForceSubdomains combines every word in the configured wordlist with
+// apex.
+func (s *Scanner) bruteForceSubdomains(apex string) []string {
+	candidates := make([]string, 0, len(s.subdomainWordlist))
+
+	for _, word := range s.subdomainWordlist {
+		candidates = append(candidates, strings.ToLower(word)+"."+apex)
+	}
+
+	return candidates
+}
+
+// permuteSubdomains generates alterations of names: common tokens
+// prepended/appended/replaced, and adjacent-number mutation (e.g.
+// "server1.example.com" -> "server2.example.com").
+func permuteSubdomains(names []string, apex string) []string {
+	var permutations []string
+
+	for _, name := range names {
+		label := strings.TrimSuffix(strings.TrimSuffix(name, apex), ".")
+		if label == "" {
+			continue
+		}
+
+		for _, token := range subdomainAlterationTokens {
+			permutations = append(permutations,
+				token+"-"+label+"."+apex,
+				label+"-"+token+"."+apex,
+			)
+		}
+
+		// Replace any hyphen-separated part of label that is itself a token
+		// with each of the other tokens, e.g. "dev-api" -> "stage-api".
+		parts := strings.Split(label, "-")
+		for i, part := range parts {
+			for _, token := range subdomainAlterationTokens {
+				if token != part {
+					continue
+				}
+
+				for _, replacement := range subdomainAlterationTokens {
+					if replacement != part {
+						replaced := append([]string(nil), parts...)
+						replaced[i] = replacement
+						permutations = append(permutations, strings.Join(replaced, "-")+"."+apex)
+					}
+				}
+			}
+		}
+
+		permutations = append(permutations, adjacentNumberMutations(label, apex)...)
+	}
+
+	return permutations
+}
+
+// adjacentNumberMutations increments and decrements a trailing number in
+// label, e.g. "server1" -> "server0" and "server2".
+func adjacentNumberMutations(label, apex string) []string {
+	i := len(label)
+	for i > 0 && label[i-1] >= '0' && label[i-1] <= '9' {
+		i--
+	}
+
+	if i == len(label) {
+		return nil
+	}
+
+	prefix := label[:i]
+
+	number, err := strconv.Atoi(label[i:])
+	if err != nil {
+		return nil
+	}
+
+	var mutations []string
+
+	if number > 0 {
+		mutations = append(mutations, fmt.Sprintf("%s%d.%s", prefix, number-1, apex))
+	}
+
+	mutations = append(mutations, fmt.Sprintf("%s%d.%s", prefix, number+1, apex))
+
+	return mutations
+}
@@ -0,0 +1,47 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAdjacentNumberMutations(t *testing.T) {
+	tests := []struct {
+		label string
+		apex  string
+		want  []string
+	}{
+		{"server1", "example.com", []string{"server0.example.com", "server2.example.com"}},
+		{"server0", "example.com", []string{"server1.example.com"}},
+		{"server", "example.com", nil},
+		{"mail99", "example.com", []string{"mail98.example.com", "mail100.example.com"}},
+	}
+
+	for _, test := range tests {
+		got := adjacentNumberMutations(test.label, test.apex)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("adjacentNumberMutations(%q, %q) = %v, want %v", test.label, test.apex, got, test.want)
+		}
+	}
+}
+
+func TestIsSubdomainOf(t *testing.T) {
+	apex := "example.com"
+
+	tests := []struct {
+		name  string
+		match bool
+	}{
+		{"example.com", true},
+		{"www.example.com", true},
+		{"evilexample.com", false},
+		{"notexample.com", false},
+		{"example.com.evil.com", false},
+	}
+
+	for _, test := range tests {
+		if got := isSubdomainOf(test.name, apex); got != test.match {
+			t.Errorf("isSubdomainOf(%q, %q) = %v, want %v", test.name, apex, got, test.match)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"github.com/GlobalCyberAlliance/domain-security-scanner/v3/pkg/cache"
+	"github.com/GlobalCyberAlliance/domain-security-scanner/v3/pkg/dns"
+)
+
+// WithCacheBackend configures the Scanner to persist cache entries through
+// backend instead of the default in-memory map, e.g. a cache.RedisBackend or
+// cache.DiskBackend, so results survive restarts and can be shared across
+// nodes scanning the same portfolio.
+func WithCacheBackend(backend cache.Backend) Option {
+	return func(scanner *Scanner) error {
+		scanner.cacheBackend = backend
+		return nil
+	}
+}
+
+// WithSubdomainDiscovery configures ScanApex to expand an apex domain into
+// candidate FQDNs using passive sources (in addition to crt.sh), a wordlist
+// brute-forced through the DNS client, and, if permute is true, name
+// alterations/permutations over whatever was discovered. A nil wordlist
+// falls back to DefaultSubdomainWordlist; pass an empty, non-nil slice to
+// disable brute-forcing entirely.
+func WithSubdomainDiscovery(sources []string, wordlist []string, permute bool) Option {
+	return func(scanner *Scanner) error {
+		if wordlist == nil {
+			wordlist = DefaultSubdomainWordlist
+		}
+
+		scanner.subdomainSources = sources
+		scanner.subdomainWordlist = wordlist
+		scanner.subdomainPermute = permute
+		return nil
+	}
+}
+
+// WithReverseSweep enables a reverse-DNS sweep of the /24 surrounding each
+// domain's MX/A infrastructure, feeding back any PTR names that share the
+// scanned domain's registrable domain as Result.Related. When active is
+// true the sweep uses activeSweepSize instead of defaultSweepSize, trading
+// more DNS traffic for wider coverage.
+func WithReverseSweep(active bool) Option {
+	return func(scanner *Scanner) error {
+		scanner.reverseSweepEnabled = true
+		scanner.reverseSweepActive = active
+		return nil
+	}
+}
+
+// WithMTASTSPolicyCache enables a persistent, auto-refreshing cache of
+// parsed MTA-STS policies stored under dir. The cache re-checks a domain's
+// TXT `id=` tag on a schedule and only re-downloads the HTTPS policy
+// document when that id has changed, so PeriodicallyRefresh can keep
+// policies current without hammering every tracked domain's mta-sts host.
+func WithMTASTSPolicyCache(dir string) Option {
+	return func(scanner *Scanner) error {
+		policyCache, err := dns.NewPolicyCache(dir,
+			func(domain string) (string, error) {
+				record, err := scanner.dnsClient.GetTypeSTSRecord(domain)
+				if err != nil {
+					return "", err
+				}
+
+				return dns.ExtractSTSID(record), nil
+			},
+			scanner.dnsClient.FetchMTASTSPolicy,
+		)
+		if err != nil {
+			return err
+		}
+
+		scanner.policyCache = policyCache
+
+		return nil
+	}
+}
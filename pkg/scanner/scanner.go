@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"runtime"
@@ -14,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cast"
+	boom "github.com/tylertreat/BoomFilters"
 )
 
 const (
@@ -22,9 +24,13 @@ const (
 
 type (
 	Scanner struct {
-		// cache is a simple in-memory cache to reduce external requests from the scanner.
+		// cache reduces external requests from the scanner by reusing recent results.
 		cache *cache.Cache[Result]
 
+		// cacheBackend is the storage engine behind cache. When nil, New falls back to
+		// an in-memory backend scoped to this process.
+		cacheBackend cache.Backend
+
 		// cacheDuration is the time-to-live for cache entries.
 		cacheDuration time.Duration
 
@@ -43,10 +49,48 @@ type (
 		// poolSize is the size of the pool of workers for the scanner.
 		poolSize uint16
 
+		// sweepPool runs reverse-DNS sweep PTR lookups. It's independent of
+		// pool so that a sweep triggered from inside an already-running scan
+		// worker (see relatedInfrastructure) never submits nested jobs into
+		// the very pool that worker is occupying a slot in, which would
+		// deadlock once every worker is simultaneously waiting on a sweep.
+		sweepPool *ants.Pool
+
 		advisor *Advisor
 
 		// scanDNSSEC is a flag to enable DNSSEC scanning.
 		scanDNSSEC bool
+
+		// subdomainSources are additional passive sources (beyond crt.sh) queried
+		// by ScanApex.
+		subdomainSources []string
+
+		// subdomainWordlist is brute-forced against the apex domain by ScanApex.
+		subdomainWordlist []string
+
+		// subdomainPermute enables name alteration/permutation over whatever
+		// ScanApex already discovered.
+		subdomainPermute bool
+
+		// reverseSweepEnabled triggers a reverse-DNS sweep of the /24 around
+		// each domain's MX/A infrastructure, surfaced as Result.Related.
+		reverseSweepEnabled bool
+
+		// reverseSweepActive selects activeSweepSize over defaultSweepSize.
+		reverseSweepActive bool
+
+		// sweepSeen deduplicates candidate IPs across every reverse sweep this
+		// Scanner performs, keeping memory bounded when scanning large
+		// portfolios on shared hosting.
+		sweepSeen *boom.StableBloomFilter
+
+		// policyCache persists parsed MTA-STS policies and keeps them fresh in
+		// the background. Set via WithMTASTSPolicyCache.
+		policyCache *dns.PolicyCache
+
+		// cancelPolicyRefresh stops the policyCache's background refresh
+		// goroutine when the Scanner is closed.
+		cancelPolicyRefresh context.CancelFunc
 	}
 
 	// Option defines a functional configuration type for a *Scanner.
@@ -54,17 +98,22 @@ type (
 
 	// Result holds the results of scanning a domain's DNS records.
 	Result struct {
-		Domain    string   `json:"domain" yaml:"domain,omitempty" doc:"The domain name being scanned." example:"example.com"`
-		Error     string   `json:"error,omitempty" yaml:"error,omitempty" doc:"An error message if the scan failed." example:"invalid domain name"`
-		BIMI      string   `json:"bimi,omitempty" yaml:"bimi,omitempty" doc:"The BIMI record for the domain." example:"https://example.com/bimi.svg"`
-		DKIM      string   `json:"dkim,omitempty" yaml:"dkim,omitempty" doc:"The DKIM record for the domain." example:"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA"`
-		DMARC     string   `json:"dmarc,omitempty" yaml:"dmarc,omitempty" doc:"The DMARC record for the domain." example:"v=DMARC1; p=none"`
-		MX        []string `json:"mx,omitempty" yaml:"mx,omitempty" doc:"The MX records for the domain." example:"aspmx.l.google.com"`
-		NS        []string `json:"ns,omitempty" yaml:"ns,omitempty" doc:"The NS records for the domain." example:"ns1.example.com"`
-		SPF       string   `json:"spf,omitempty" yaml:"spf,omitempty" doc:"The SPF record for the domain." example:"v=spf1 include:_spf.google.com ~all"`
-		STS       string   `json:"mta-sts,omitempty" yaml:"mta-sts,omitempty" doc:"The MTA-STS record for the domain." example:"v=STSv1; id=20210803T010200;"`
-		STSPolicy string   `json:"mta-sts-policy,omitempty" yaml:"mta-sts-policy,omitempty" doc:"The MTA-STS policy for the domain." example:"version: STSv1\nmode: enforce\nmx: mail.example.com\nmx: *.example.net\nmax_age: 86400\n"`
-		DNSSEC    string   `json:"dnssec,omitempty" yaml:"dnssec,omitempty" doc:"The DNSSEC record for the domain." example:"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA"`
+		Domain     string              `json:"domain" yaml:"domain,omitempty" doc:"The domain name being scanned." example:"example.com"`
+		Error      string              `json:"error,omitempty" yaml:"error,omitempty" doc:"An error message if the scan failed." example:"invalid domain name"`
+		BIMI       string              `json:"bimi,omitempty" yaml:"bimi,omitempty" doc:"The BIMI record for the domain." example:"v=BIMI1; l=https://example.com/bimi.svg"`
+		DKIM       string              `json:"dkim,omitempty" yaml:"dkim,omitempty" doc:"The DKIM record for the domain." example:"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA"`
+		DMARC      string              `json:"dmarc,omitempty" yaml:"dmarc,omitempty" doc:"The DMARC record for the domain." example:"v=DMARC1; p=none"`
+		MX         []string            `json:"mx,omitempty" yaml:"mx,omitempty" doc:"The MX records for the domain." example:"aspmx.l.google.com"`
+		NS         []string            `json:"ns,omitempty" yaml:"ns,omitempty" doc:"The NS records for the domain." example:"ns1.example.com"`
+		SPF        string              `json:"spf,omitempty" yaml:"spf,omitempty" doc:"The SPF record for the domain." example:"v=spf1 include:_spf.google.com ~all"`
+		STS        string              `json:"mta-sts,omitempty" yaml:"mta-sts,omitempty" doc:"The MTA-STS record for the domain." example:"v=STSv1; id=20210803T010200;"`
+		STSPolicy  string              `json:"mta-sts-policy,omitempty" yaml:"mta-sts-policy,omitempty" doc:"The MTA-STS policy for the domain." example:"version: STSv1\nmode: enforce\nmx: mail.example.com\nmx: *.example.net\nmax_age: 86400\n"`
+		TLSRPT     string              `json:"tls-rpt,omitempty" yaml:"tls-rpt,omitempty" doc:"The TLS-RPT record for the domain." example:"v=TLSRPTv1; rua=mailto:reports@example.com"`
+		TLSA       map[string][]string `json:"tlsa,omitempty" yaml:"tlsa,omitempty" doc:"The TLSA (DANE) records for each MX host, keyed by hostname." example:"mail.example.com:3 1 1 0123456789abcdef"`
+		TLSADNSSEC map[string]bool     `json:"tlsaDnssec,omitempty" yaml:"tlsaDnssec,omitempty" doc:"Whether the resolver authenticated the DNSSEC chain for each MX host's own TLSA lookup, keyed by hostname. This is the MX host's zone, which may differ from the scanned domain's." example:"mail.example.com:true"`
+		Related    []string            `json:"related,omitempty" yaml:"related,omitempty" doc:"Hostnames discovered via reverse-DNS sweep that share this domain's registrable domain." example:"shadow-it.example.com"`
+		DNSSEC     string              `json:"dnssec,omitempty" yaml:"dnssec,omitempty" doc:"The domain's DNSKEY record(s), populated only if the configured resolver authenticated the DNSSEC chain. Empty means the zone is unsigned or wasn't validated." example:"example.com. 3600 IN DNSKEY 257 3 13mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="`
+		Findings   []Finding           `json:"findings,omitempty" yaml:"findings,omitempty" doc:"Advisory findings raised about the domain's records."`
 	}
 )
 
@@ -92,7 +141,7 @@ func New(logger zerolog.Logger, timeout time.Duration, opts ...Option) (*Scanner
 	}
 
 	// Initialize cache
-	scanner.cache = cache.New[Result](scanner.cacheDuration)
+	scanner.cache = cache.New[Result](scanner.cacheDuration, scanner.cacheBackend)
 
 	// Create a new pool of workers for the scanner
 	pool, err := ants.NewPool(int(scanner.poolSize), ants.WithExpiryDuration(timeout), ants.WithPanicHandler(func(err interface{}) {
@@ -104,6 +153,32 @@ func New(logger zerolog.Logger, timeout time.Duration, opts ...Option) (*Scanner
 
 	scanner.pool = pool
 
+	// Sized independently of poolSize: sweeps are many short-lived PTR
+	// lookups rather than CPU-bound work, so a larger pool is cheap, and it
+	// must never be sized to (or share a capacity pool with) the scanner's
+	// own worker pool, or nested sweeps submitted from a scan worker could
+	// deadlock waiting for a free slot that worker itself occupies.
+	sweepPool, err := ants.NewPool(sweepPoolSize, ants.WithExpiryDuration(timeout), ants.WithPanicHandler(func(err interface{}) {
+		scanner.logger.Error().Err(errors.New(cast.ToString(err))).Msg("unrecoverable panic occurred during reverse-DNS sweep")
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sweep pool: %w", err)
+	}
+
+	scanner.sweepPool = sweepPool
+
+	if scanner.reverseSweepEnabled {
+		scanner.sweepSeen = boom.NewDefaultStableBloomFilter(1000000, 0.01)
+	}
+
+	if scanner.policyCache != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		scanner.cancelPolicyRefresh = cancel
+		scanner.advisor.policyCache = scanner.policyCache
+
+		go scanner.policyCache.PeriodicallyRefresh(ctx)
+	}
+
 	return scanner, nil
 }
 
@@ -153,7 +228,9 @@ func (s *Scanner) Scan(domains ...string) ([]*Result, error) {
 				s.logger.Debug().Msg("cache miss for " + domainToScan)
 
 				defer func() {
-					s.cache.Set(domainToScan, result)
+					if err := s.cache.Set(domainToScan, result); err != nil {
+						s.logger.Error().Err(err).Str("domain", domainToScan).Msg("failed to write scan result to cache")
+					}
 				}()
 			}
 
@@ -179,7 +256,7 @@ func (s *Scanner) Scan(domains ...string) ([]*Result, error) {
 
 			var errs []string
 			scanWg := sync.WaitGroup{}
-			scanWg.Add(7)
+			scanWg.Add(8)
 
 			// Get BIMI record
 			go func() {
@@ -235,6 +312,15 @@ func (s *Scanner) Scan(domains ...string) ([]*Result, error) {
 				}
 			}()
 
+			// Get TLS-RPT record
+			go func() {
+				defer scanWg.Done()
+				result.TLSRPT, err = s.dnsClient.GetTypeTLSRPT(domainToScan)
+				if err != nil {
+					errs = append(errs, "tls-rpt:"+err.Error())
+				}
+			}()
+
 			go func() {
 				defer scanWg.Done()
 				if s.scanDNSSEC {
@@ -251,6 +337,86 @@ func (s *Scanner) Scan(domains ...string) ([]*Result, error) {
 				result.Error = strings.Join(errs, "; ")
 			}
 
+			// Resolve DANE/TLSA records for every MX host discovered above. This
+			// has to happen after scanWg.Wait() since it depends on result.MX.
+			if len(result.MX) > 0 {
+				result.TLSA = make(map[string][]string, len(result.MX))
+				result.TLSADNSSEC = make(map[string]bool, len(result.MX))
+
+				var tlsaMutex sync.Mutex
+				tlsaWg := sync.WaitGroup{}
+
+				for _, mxHost := range result.MX {
+					tlsaWg.Add(1)
+
+					go func(mxHost string) {
+						defer tlsaWg.Done()
+
+						records, authenticated, err := s.dnsClient.GetTypeTLSA(mxHost)
+						if err != nil {
+							return
+						}
+
+						tlsaMutex.Lock()
+						result.TLSA[mxHost] = records
+						result.TLSADNSSEC[mxHost] = authenticated
+						tlsaMutex.Unlock()
+					}(mxHost)
+				}
+
+				tlsaWg.Wait()
+			}
+
+			// Reverse-sweep the infrastructure around this domain's MX/A hosts for
+			// shadow-IT mail servers sharing its registrable domain.
+			if s.reverseSweepEnabled {
+				var relatedMutex sync.Mutex
+
+				seenRelated := map[string]bool{}
+				hosts := append([]string{domainToScan}, result.MX...)
+
+				relatedWg := sync.WaitGroup{}
+
+				for _, host := range hosts {
+					host := host
+
+					relatedWg.Add(1)
+
+					go func() {
+						defer relatedWg.Done()
+
+						names := s.relatedInfrastructure(domainToScan, host)
+
+						relatedMutex.Lock()
+						defer relatedMutex.Unlock()
+
+						for _, name := range names {
+							if !seenRelated[name] {
+								seenRelated[name] = true
+								result.Related = append(result.Related, name)
+							}
+						}
+					}()
+				}
+
+				relatedWg.Wait()
+			}
+
+			if s.advisor != nil {
+				result.Findings = s.advisor.Advise(result)
+			}
+
+			// Seed/update the policy cache with whatever this scan just saw, so
+			// PeriodicallyRefresh has the domain to track and the next scan's
+			// advisor check can detect an id change.
+			if s.policyCache != nil && result.STSPolicy != "" {
+				if policy, err := dns.ParseMTASTSPolicy(dns.ExtractSTSID(result.STS), result.STSPolicy); err == nil {
+					if err := s.policyCache.Put(domainToScan, policy); err != nil {
+						s.logger.Error().Err(err).Str("domain", domainToScan).Msg("failed to persist mta-sts policy")
+					}
+				}
+			}
+
 			mutex.Lock()
 			results = append(results, result)
 			mutex.Unlock()
@@ -293,7 +459,19 @@ func (s *Scanner) ScanZone(zone io.Reader) ([]*Result, error) {
 
 // Close closes the scanner
 func (s *Scanner) Close() {
+	if s.cancelPolicyRefresh != nil {
+		s.cancelPolicyRefresh()
+	}
+
 	s.pool.Release()
-	s.cache.Flush()
+	s.sweepPool.Release()
+
+	// Only flush the cache when it's backed by the default in-memory
+	// store; a caller-supplied backend (e.g. Redis or disk) is there
+	// specifically so results survive across processes and restarts.
+	if s.cacheBackend == nil {
+		s.cache.Flush()
+	}
+
 	s.logger.Debug().Msg("scanner closed")
 }
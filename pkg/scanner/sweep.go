@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	// defaultSweepSize is how many consecutive addresses are PTR-swept around
+	// a discovered MX/A host when reverse sweeping is enabled but not running
+	// in "active" mode.
+	defaultSweepSize = 250
+
+	// activeSweepSize is used instead of defaultSweepSize when the reverse
+	// sweep was enabled in active mode. The sweep never leaves the /24
+	// enclosing the host, so this covers all 256 addresses of it rather
+	// than the first defaultSweepSize.
+	activeSweepSize = 500
+
+	// sweepPoolSize bounds how many PTR lookups a reverse sweep runs
+	// concurrently. It's independent of the scanner's main pool size; see
+	// Scanner.sweepPool.
+	sweepPoolSize = 64
+
+	// maxCIDRSweepSize caps how many addresses ScanCIDR will sweep for a
+	// single call, regardless of how large the supplied CIDR is, so a
+	// caller passing e.g. a /8 doesn't trigger millions of PTR lookups.
+	maxCIDRSweepSize = 65536
+)
+
+// ScanCIDR PTR-resolves every address in cidr and scans every hostname
+// discovered that way. The sweep covers the whole network, up to
+// maxCIDRSweepSize addresses; if cidr holds more than that, the sweep is
+// truncated and a warning is logged.
+func (s *Scanner) ScanCIDR(cidr string) ([]*Result, error) {
+	if s.pool == nil {
+		return nil, errors.New("scanner is closed")
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse cidr")
+	}
+
+	size, truncated := cidrSweepSize(network)
+	if truncated {
+		s.logger.Warn().Str("cidr", cidr).Int("swept", size).Msg("cidr exceeds the sweep size cap; truncating")
+	}
+
+	hostnames := s.sweepPTR(network, size)
+	if len(hostnames) == 0 {
+		return nil, nil
+	}
+
+	return s.Scan(hostnames...)
+}
+
+// cidrSweepSize returns how many addresses of network to sweep, capped at
+// maxCIDRSweepSize, along with whether that cap truncated the network's
+// actual size.
+func cidrSweepSize(network *net.IPNet) (size int, truncated bool) {
+	ones, bits := network.Mask.Size()
+	hostBits := bits - ones
+
+	// A hostBits of 32 or more would overflow a 32-bit shift (and, well
+	// below that, already dwarfs the cap), so just treat it as truncated.
+	if hostBits >= 32 || 1<<uint(hostBits) > maxCIDRSweepSize {
+		return maxCIDRSweepSize, true
+	}
+
+	return 1 << uint(hostBits), false
+}
+
+// sweepSize returns how many consecutive addresses a reverse sweep should
+// cover, honoring the scanner's active/default sizing.
+func (s *Scanner) sweepSize() int {
+	if s.reverseSweepActive {
+		return activeSweepSize
+	}
+
+	return defaultSweepSize
+}
+
+// sweepPTR issues PTR queries across the first size addresses of network,
+// deduplicating candidate IPs against the scanner's Bloom filter so repeated
+// sweeps over shared hosting don't blow up memory or DNS traffic.
+func (s *Scanner) sweepPTR(network *net.IPNet, size int) []string {
+	var mutex sync.Mutex
+	var hostnames []string
+	var wg sync.WaitGroup
+
+	base := network.IP.Mask(network.Mask)
+
+	for i := 0; i < size; i++ {
+		candidate := make(net.IP, len(base))
+		copy(candidate, base)
+		incrementIP(candidate, i)
+
+		if !network.Contains(candidate) {
+			break
+		}
+
+		if s.sweepSeen != nil && s.sweepSeen.TestAndAdd([]byte(candidate.String())) {
+			continue
+		}
+
+		candidateStr := candidate.String()
+
+		wg.Add(1)
+
+		if err := s.sweepPool.Submit(func() {
+			defer wg.Done()
+
+			names, err := s.dnsClient.GetTypePTR(candidateStr)
+			if err != nil || len(names) == 0 {
+				return
+			}
+
+			mutex.Lock()
+			hostnames = append(hostnames, names...)
+			mutex.Unlock()
+		}); err != nil {
+			wg.Done()
+		}
+	}
+
+	wg.Wait()
+
+	return hostnames
+}
+
+// incrementIP adds n to ip in place, treating it as a big-endian integer.
+func incrementIP(ip net.IP, n int) {
+	for i := len(ip) - 1; i >= 0 && n > 0; i-- {
+		sum := int(ip[i]) + n
+		ip[i] = byte(sum % 256)
+		n = sum / 256
+	}
+}
+
+// relatedInfrastructure reverse-sweeps the /24 enclosing host's address and
+// returns the PTR names that share domain's registrable domain (eTLD+1) --
+// infrastructure an operator would want surfaced even though it wasn't in
+// their original domain list, e.g. shadow-IT mail servers.
+func (s *Scanner) relatedInfrastructure(domain, host string) []string {
+	addresses, err := s.dnsClient.GetTypeA(host)
+	if err != nil || len(addresses) == 0 {
+		return nil
+	}
+
+	network := &net.IPNet{
+		IP:   addresses[0].Mask(net.CIDRMask(24, 32)),
+		Mask: net.CIDRMask(24, 32),
+	}
+
+	registrable, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return nil
+	}
+
+	var related []string
+
+	for _, name := range s.sweepPTR(network, s.sweepSize()) {
+		if name == host {
+			continue
+		}
+
+		candidateRegistrable, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimSuffix(name, "."))
+		if err == nil && candidateRegistrable == registrable {
+			related = append(related, name)
+		}
+	}
+
+	return related
+}
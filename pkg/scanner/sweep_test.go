@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GlobalCyberAlliance/domain-security-scanner/v3/pkg/dns"
+	"github.com/panjf2000/ants/v2"
+	"github.com/rs/zerolog"
+)
+
+func TestCIDRSweepSize(t *testing.T) {
+	tests := []struct {
+		cidr          string
+		wantSize      int
+		wantTruncated bool
+	}{
+		{"192.168.1.0/24", 256, false},
+		{"10.0.0.0/16", maxCIDRSweepSize, false},
+		{"10.0.0.0/8", maxCIDRSweepSize, true},
+		{"203.0.113.4/30", 4, false},
+		{"2001:db8::/120", 256, false},
+		{"2001:db8::/32", maxCIDRSweepSize, true},
+	}
+
+	for _, test := range tests {
+		_, network, err := net.ParseCIDR(test.cidr)
+		if err != nil {
+			t.Fatalf("ParseCIDR(%q): %v", test.cidr, err)
+		}
+
+		size, truncated := cidrSweepSize(network)
+		if size != test.wantSize || truncated != test.wantTruncated {
+			t.Errorf("cidrSweepSize(%q) = (%d, %v), want (%d, %v)", test.cidr, size, truncated, test.wantSize, test.wantTruncated)
+		}
+	}
+}
+
+// TestSweepPTRDoesNotDeadlockNestedInScanPool reproduces the scenario from
+// relatedInfrastructure: a worker already running inside the scanner's main
+// pool calls sweepPTR, which itself needs to submit many jobs and wait on
+// them. With more outer workers in flight than the main pool has capacity
+// for, submitting the nested sweep jobs to that same pool would deadlock
+// (every worker blocked waiting on nested work that no free worker remains
+// to run). sweepPTR must submit to its own, independent sweepPool instead.
+func TestSweepPTRDoesNotDeadlockNestedInScanPool(t *testing.T) {
+	dnsClient, err := dns.New(50*time.Millisecond, 512, 65535, "127.0.0.1")
+	if err != nil {
+		t.Fatalf("dns.New: %v", err)
+	}
+
+	pool, err := ants.NewPool(2)
+	if err != nil {
+		t.Fatalf("ants.NewPool(main): %v", err)
+	}
+	defer pool.Release()
+
+	sweepPool, err := ants.NewPool(sweepPoolSize)
+	if err != nil {
+		t.Fatalf("ants.NewPool(sweep): %v", err)
+	}
+	defer sweepPool.Release()
+
+	s := &Scanner{
+		pool:      pool,
+		sweepPool: sweepPool,
+		dnsClient: dnsClient,
+		logger:    zerolog.Nop(),
+	}
+
+	_, network, err := net.ParseCIDR("10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %v", err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		var wg sync.WaitGroup
+
+		// More outer tasks than the main pool has workers for, so every
+		// worker ends up occupied by one of these before any nested sweep
+		// work is submitted.
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+
+			if err := pool.Submit(func() {
+				defer wg.Done()
+				s.sweepPTR(network, defaultSweepSize)
+			}); err != nil {
+				wg.Done()
+			}
+		}
+
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("sweepPTR deadlocked when nested inside the scan pool")
+	}
+}
@@ -0,0 +1,15 @@
+package scanner
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed wordlists/subdomains.txt
+var defaultSubdomainWordlistRaw string
+
+// DefaultSubdomainWordlist is a small set of common mail and infrastructure
+// labels (mail, mx, vpn, autodiscover, etc.), bundled so WithSubdomainDiscovery
+// brute-forces something useful out of the box even when the caller doesn't
+// supply its own wordlist.
+var DefaultSubdomainWordlist = strings.Fields(defaultSubdomainWordlistRaw)
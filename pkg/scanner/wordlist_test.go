@@ -0,0 +1,15 @@
+package scanner
+
+import "testing"
+
+func TestDefaultSubdomainWordlist(t *testing.T) {
+	if len(DefaultSubdomainWordlist) == 0 {
+		t.Fatal("DefaultSubdomainWordlist is empty")
+	}
+
+	for _, word := range DefaultSubdomainWordlist {
+		if word == "" {
+			t.Fatal("DefaultSubdomainWordlist contains an empty entry")
+		}
+	}
+}